@@ -0,0 +1,58 @@
+package migratev1
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+)
+
+// TestReceiveRequestOneofRoundTrip guards against the Data oneof silently
+// dropping its payload: proto.Marshal has to actually wire-encode whichever
+// of Metadata or Chunk is set, and proto.Unmarshal has to recover it.
+func TestReceiveRequestOneofRoundTrip(t *testing.T) {
+	metaReq := &ReceiveRequest{
+		Data: &ReceiveRequest_Metadata{
+			Metadata: &ReceiveMetadata{
+				Namespace:      "example",
+				NewContainerId: "restored-nginx",
+			},
+		},
+	}
+
+	b, err := proto.Marshal(metaReq)
+	if err != nil {
+		t.Fatalf("Marshal(metadata) error: %v", err)
+	}
+	if len(b) == 0 {
+		t.Fatal("Marshal(metadata) produced 0 bytes")
+	}
+
+	var gotMeta ReceiveRequest
+	if err := proto.Unmarshal(b, &gotMeta); err != nil {
+		t.Fatalf("Unmarshal(metadata) error: %v", err)
+	}
+	if got := gotMeta.GetMetadata(); got == nil || got.GetNamespace() != "example" || got.GetNewContainerId() != "restored-nginx" {
+		t.Fatalf("Unmarshal(metadata) = %+v, want namespace=example new_container_id=restored-nginx", got)
+	}
+
+	chunkReq := &ReceiveRequest{
+		Data: &ReceiveRequest_Chunk{Chunk: []byte("checkpoint-bytes")},
+	}
+
+	b, err = proto.Marshal(chunkReq)
+	if err != nil {
+		t.Fatalf("Marshal(chunk) error: %v", err)
+	}
+	if len(b) == 0 {
+		t.Fatal("Marshal(chunk) produced 0 bytes")
+	}
+
+	var gotChunk ReceiveRequest
+	if err := proto.Unmarshal(b, &gotChunk); err != nil {
+		t.Fatalf("Unmarshal(chunk) error: %v", err)
+	}
+	if !bytes.Equal(gotChunk.GetChunk(), []byte("checkpoint-bytes")) {
+		t.Fatalf("Unmarshal(chunk) = %q, want %q", gotChunk.GetChunk(), "checkpoint-bytes")
+	}
+}
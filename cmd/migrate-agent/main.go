@@ -0,0 +1,76 @@
+// Command migrate-agent runs on a migration destination host, exposing
+// the receive side of pkg/migrate over a mutually-authenticated gRPC
+// connection.
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"flag"
+	"log"
+	"net"
+	"os"
+
+	"github.com/containerd/containerd"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	migratev1 "github.com/ihcsim/container-snapshotter/api/migrate/v1"
+	"github.com/ihcsim/container-snapshotter/pkg/migrate"
+)
+
+func main() {
+	var (
+		containerdSocket = flag.String("containerd-socket", "/run/containerd/containerd.sock", "containerd gRPC socket")
+		addr             = flag.String("address", "0.0.0.0:9443", "address the migration agent listens on")
+		certFile         = flag.String("cert-file", "", "TLS certificate presented to source agents")
+		keyFile          = flag.String("key-file", "", "TLS private key matching -cert-file")
+		caFile           = flag.String("ca-file", "", "CA bundle used to verify connecting source agents")
+	)
+	flag.Parse()
+
+	client, err := containerd.New(*containerdSocket)
+	if err != nil {
+		log.Fatalf("connect to containerd: %v", err)
+	}
+	defer client.Close()
+
+	tlsConfig, err := serverTLSConfig(*certFile, *keyFile, *caFile)
+	if err != nil {
+		log.Fatalf("load TLS material: %v", err)
+	}
+
+	listener, err := net.Listen("tcp", *addr)
+	if err != nil {
+		log.Fatalf("listen on %s: %v", *addr, err)
+	}
+
+	server := grpc.NewServer(grpc.Creds(credentials.NewTLS(tlsConfig)))
+	migratev1.RegisterMigrationAgentServer(server, migrate.NewAgent(client))
+
+	log.Printf("migration agent listening on %s", *addr)
+	log.Fatal(server.Serve(listener))
+}
+
+func serverTLSConfig(certFile, keyFile, caFile string) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	caBytes, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, err
+	}
+
+	caPool := x509.NewCertPool()
+	if !caPool.AppendCertsFromPEM(caBytes) {
+		return nil, os.ErrInvalid
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    caPool,
+	}, nil
+}
@@ -0,0 +1,164 @@
+// Command snapshotter-cli is a thin client for driving a running
+// snapshotterd instance remotely.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	snapshotterv1 "github.com/ihcsim/container-snapshotter/api/snapshotter/v1"
+)
+
+func main() {
+	var (
+		addr      = flag.String("address", "127.0.0.1:9090", "snapshotterd gRPC address")
+		namespace = flag.String("namespace", "example", "containerd namespace")
+	)
+	flag.Parse()
+
+	args := flag.Args()
+	if len(args) < 1 {
+		log.Fatal("usage: snapshotter-cli [-address addr] [-namespace ns] <checkpoint|list|export|import|restore|delete> [args]")
+	}
+
+	conn, err := grpc.Dial(*addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		log.Fatalf("dial %s: %v", *addr, err)
+	}
+	defer conn.Close()
+
+	client := snapshotterv1.NewSnapshotterClient(conn)
+	ctx := context.Background()
+
+	switch cmd := args[0]; cmd {
+	case "checkpoint":
+		if len(args) < 2 {
+			log.Fatal("usage: snapshotter-cli checkpoint <container-id>")
+		}
+		checkpoint, err := client.CreateCheckpoint(ctx, &snapshotterv1.CreateCheckpointRequest{
+			Namespace:   *namespace,
+			ContainerId: args[1],
+		})
+		if err != nil {
+			log.Fatalf("checkpoint: %v", err)
+		}
+		fmt.Println(checkpoint.Name)
+
+	case "list":
+		resp, err := client.ListCheckpoints(ctx, &snapshotterv1.ListCheckpointsRequest{Namespace: *namespace})
+		if err != nil {
+			log.Fatalf("list: %v", err)
+		}
+		for _, checkpoint := range resp.Checkpoints {
+			fmt.Printf("%s\t%s\n", checkpoint.ContainerId, checkpoint.Name)
+		}
+
+	case "export":
+		if len(args) < 3 {
+			log.Fatal("usage: snapshotter-cli export <checkpoint-name> <output-file>")
+		}
+		stream, err := client.ExportCheckpoint(ctx, &snapshotterv1.ExportCheckpointRequest{
+			Namespace: *namespace,
+			Name:      args[1],
+		})
+		if err != nil {
+			log.Fatalf("export: %v", err)
+		}
+
+		out, err := os.Create(args[2])
+		if err != nil {
+			log.Fatalf("export: %v", err)
+		}
+		defer out.Close()
+
+		for {
+			chunk, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				log.Fatalf("export: %v", err)
+			}
+			if _, err := out.Write(chunk.Chunk); err != nil {
+				log.Fatalf("export: %v", err)
+			}
+		}
+
+	case "import":
+		if len(args) < 2 {
+			log.Fatal("usage: snapshotter-cli import <input-file>")
+		}
+		in, err := os.Open(args[1])
+		if err != nil {
+			log.Fatalf("import: %v", err)
+		}
+		defer in.Close()
+
+		stream, err := client.ImportCheckpoint(ctx)
+		if err != nil {
+			log.Fatalf("import: %v", err)
+		}
+		if err := stream.Send(&snapshotterv1.ImportCheckpointRequest{Namespace: *namespace}); err != nil {
+			log.Fatalf("import: %v", err)
+		}
+
+		buf := make([]byte, 32*1024)
+		for {
+			n, readErr := in.Read(buf)
+			if n > 0 {
+				chunk := append([]byte(nil), buf[:n]...)
+				if err := stream.Send(&snapshotterv1.ImportCheckpointRequest{Chunk: chunk}); err != nil {
+					log.Fatalf("import: %v", err)
+				}
+			}
+			if readErr == io.EOF {
+				break
+			}
+			if readErr != nil {
+				log.Fatalf("import: %v", readErr)
+			}
+		}
+
+		checkpoint, err := stream.CloseAndRecv()
+		if err != nil {
+			log.Fatalf("import: %v", err)
+		}
+		fmt.Println(checkpoint.Name)
+
+	case "restore":
+		if len(args) < 3 {
+			log.Fatal("usage: snapshotter-cli restore <checkpoint-name> <new-container-id>")
+		}
+		resp, err := client.Restore(ctx, &snapshotterv1.RestoreRequest{
+			Namespace:      *namespace,
+			Name:           args[1],
+			NewContainerId: args[2],
+		})
+		if err != nil {
+			log.Fatalf("restore: %v", err)
+		}
+		fmt.Println(resp.ContainerId)
+
+	case "delete":
+		if len(args) < 2 {
+			log.Fatal("usage: snapshotter-cli delete <checkpoint-name>")
+		}
+		if _, err := client.DeleteCheckpoint(ctx, &snapshotterv1.DeleteCheckpointRequest{
+			Namespace: *namespace,
+			Name:      args[1],
+		}); err != nil {
+			log.Fatalf("delete: %v", err)
+		}
+
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n", cmd)
+		os.Exit(1)
+	}
+}
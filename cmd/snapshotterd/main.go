@@ -0,0 +1,108 @@
+// Command snapshotterd runs the Snapshotter gRPC service, with an HTTP
+// gateway and Prometheus metrics endpoint, backed by a single containerd
+// client. Setting -scheduler-namespace and -scheduler-interval also
+// starts a background snapshotter.Scheduler that takes automatic,
+// rotated checkpoints of every container present in that namespace at
+// startup.
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/namespaces"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+
+	snapshotterv1 "github.com/ihcsim/container-snapshotter/api/snapshotter/v1"
+	"github.com/ihcsim/container-snapshotter/pkg/daemon"
+	"github.com/ihcsim/container-snapshotter/pkg/snapshotter"
+)
+
+func main() {
+	var (
+		containerdSocket   = flag.String("containerd-socket", "/run/containerd/containerd.sock", "containerd gRPC socket")
+		grpcAddr           = flag.String("grpc-address", "0.0.0.0:9090", "address the gRPC service listens on")
+		httpAddr           = flag.String("http-address", "0.0.0.0:9091", "address the HTTP gateway and metrics endpoint listen on")
+		schedulerNamespace = flag.String("scheduler-namespace", "", "namespace to automatically checkpoint on an interval; disabled when empty")
+		schedulerInterval  = flag.Duration("scheduler-interval", 0, "interval between automatic checkpoints of every container in -scheduler-namespace")
+		schedulerKeepLast  = flag.Int("scheduler-keep-last", 3, "number of most recent automatic checkpoints to retain per container")
+		schedulerMaxAge    = flag.Duration("scheduler-max-age", 0, "maximum age of an automatic checkpoint before it's pruned; disabled when zero")
+	)
+	flag.Parse()
+
+	client, err := containerd.New(*containerdSocket)
+	if err != nil {
+		log.Fatalf("connect to containerd: %v", err)
+	}
+	defer client.Close()
+
+	metrics := daemon.NewMetrics()
+	server := daemon.NewServer(client, metrics)
+
+	if *schedulerNamespace != "" && *schedulerInterval > 0 {
+		go runScheduler(client, *schedulerNamespace, *schedulerInterval, snapshotter.RetentionPolicy{
+			KeepLast: *schedulerKeepLast,
+			MaxAge:   *schedulerMaxAge,
+		})
+	}
+
+	grpcServer := grpc.NewServer()
+	snapshotterv1.RegisterSnapshotterServer(grpcServer, server)
+
+	listener, err := net.Listen("tcp", *grpcAddr)
+	if err != nil {
+		log.Fatalf("listen on %s: %v", *grpcAddr, err)
+	}
+
+	go func() {
+		log.Printf("gRPC service listening on %s", *grpcAddr)
+		if err := grpcServer.Serve(listener); err != nil {
+			log.Fatalf("serve gRPC: %v", err)
+		}
+	}()
+
+	ctx := context.Background()
+	gateway := runtime.NewServeMux()
+	if err := snapshotterv1.RegisterSnapshotterHandlerServer(ctx, gateway, server); err != nil {
+		log.Fatalf("register HTTP gateway: %v", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/v1/", gateway)
+	mux.Handle("/metrics", promhttp.Handler())
+
+	log.Printf("HTTP gateway listening on %s", *httpAddr)
+	log.Fatal(http.ListenAndServe(*httpAddr, mux))
+}
+
+// runScheduler watches every container in namespace at the time it is
+// called and checkpoints them on every tick of interval until the process
+// exits; containers created later are not watched. Pointing
+// snapshotterd at a namespace is enough to get automatic hot backups
+// without driving checkpoints by hand through the gRPC/HTTP API.
+func runScheduler(client *containerd.Client, namespace string, interval time.Duration, retention snapshotter.RetentionPolicy) {
+	ctx := namespaces.WithNamespace(context.Background(), namespace)
+
+	containers, err := client.Containers(ctx)
+	if err != nil {
+		log.Printf("scheduler: list containers in %s: %v", namespace, err)
+		return
+	}
+
+	sched := snapshotter.NewScheduler(snapshotter.New(client, namespace), interval, retention)
+	for _, container := range containers {
+		sched.Watch(container)
+	}
+
+	log.Printf("scheduler: checkpointing %d container(s) in namespace %q every %s", len(containers), namespace, interval)
+	if err := sched.Start(ctx); err != nil {
+		log.Printf("scheduler: stopped: %v", err)
+	}
+}
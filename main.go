@@ -11,9 +11,11 @@ import (
 
 	"github.com/containerd/containerd"
 	"github.com/containerd/containerd/cio"
-	"github.com/containerd/containerd/images/archive"
 	"github.com/containerd/containerd/namespaces"
 	"github.com/containerd/containerd/oci"
+
+	"github.com/ihcsim/container-snapshotter/pkg/reconciler"
+	"github.com/ihcsim/container-snapshotter/pkg/snapshotter"
 )
 
 const (
@@ -35,26 +37,33 @@ func main() {
 	}
 
 	ctx := namespaces.WithNamespace(context.Background(), "example")
+
+	rec := reconciler.New(client, namespace)
 	defer func() {
-		if err := cleanup(ctx, client); err != nil {
+		if err := cleanup(ctx, client, rec); err != nil {
 			log.Print(err)
 			os.Exit(127)
 		}
 		client.Close()
 	}()
 
-	container, wait, err := start(ctx, client)
+	container, task, wait, err := start(ctx, client)
 	if err != nil {
 		log.Fatal(err)
 	}
+	rec.Track(container, task)
 
-	archiveFile, err := snapshots(ctx, client, container)
-	if err != nil {
-		log.Print(err)
-	}
+	recCtx, cancelRec := context.WithCancel(ctx)
+	defer cancelRec()
+	recErr := make(chan error, 1)
+	go func() { recErr <- rec.Run(recCtx) }()
 
-	if _, _, err := restore(ctx, archiveFile); err != nil {
+	snap := snapshotter.New(client, namespace)
+	restored, restoredTask, err := checkpointAndRestore(ctx, snap, container)
+	if err != nil {
 		log.Print(err)
+	} else {
+		rec.Track(restored, restoredTask)
 	}
 
 	for {
@@ -70,6 +79,11 @@ func main() {
 			}
 			log.Print(details)
 			return
+		case err := <-recErr:
+			if err != nil {
+				log.Printf("reconciler stopped: %v", err)
+			}
+			return
 		}
 	}
 }
@@ -83,10 +97,10 @@ func initClients() error {
 	return nil
 }
 
-func start(ctx context.Context, client *containerd.Client) (containerd.Container, <-chan containerd.ExitStatus, error) {
+func start(ctx context.Context, client *containerd.Client) (containerd.Container, containerd.Task, <-chan containerd.ExitStatus, error) {
 	image, err := client.Pull(ctx, imageName, containerd.WithPullUnpack)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 	log.Printf("pulled image: %s", image.Name())
 
@@ -97,97 +111,66 @@ func start(ctx context.Context, client *containerd.Client) (containerd.Container
 		containerd.WithNewSpec(oci.WithImageConfig(image)),
 	)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
 	task, err := container.NewTask(ctx, cio.NewCreator(cio.WithStdio))
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
 	wait, err := task.Wait(ctx)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
 	if err := task.Start(ctx); err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
-	return container, wait, nil
+	return container, task, wait, nil
 }
 
-func snapshots(ctx context.Context, client *containerd.Client, container containerd.Container) (*os.File, error) {
-	var (
-		now          = time.Now().Format("01-02-2006-15:04:05")
-		snapshotName = fmt.Sprintf("isim.dev/checkpoint/container/%s:%s", container.ID(), now)
-	)
-
-	containerImg, err := container.Checkpoint(
-		ctx,
-		snapshotName,
-		containerd.WithCheckpointRuntime,
-		containerd.WithCheckpointTask)
+// checkpointAndRestore checkpoints container to a local tarball and
+// immediately restores it under a new name, exercising the full
+// Checkpoint -> Export -> Import -> Restore flow exposed by the
+// snapshotter package. It returns the restored container and task so the
+// caller can track them alongside the original.
+func checkpointAndRestore(ctx context.Context, snap *snapshotter.Snapshotter, container containerd.Container) (containerd.Container, containerd.Task, error) {
+	snapshot, err := snap.Checkpoint(ctx, container, "")
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	log.Printf("created container snapshot: %s", containerImg.Name())
+	log.Printf("created container snapshot: %s", snapshot.Name())
 
-	return export(ctx, containerImg)
-}
-
-func export(ctx context.Context, image containerd.Image) (*os.File, error) {
-	createdAt := image.Metadata().CreatedAt.Format("01-02-2006-15:04:05")
-	archiveFile, err := os.Create(fmt.Sprintf("snapshot-%s.tar", createdAt))
+	archiveFile, err := os.Create(fmt.Sprintf("snapshot-%s.tar", snapshot.CreatedAt().Format("01-02-2006-15:04:05")))
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
+	defer archiveFile.Close()
 
-	if err := client.Export(
-		ctx,
-		archiveFile,
-		archive.WithImage(client.ImageService(), image.Name())); err != nil {
-		return nil, err
+	if err := snap.Export(ctx, snapshot, archiveFile); err != nil {
+		return nil, nil, err
 	}
 
-	return archiveFile, nil
-}
-
-func restore(ctx context.Context, archiveFile *os.File) (containerd.Container, <-chan containerd.ExitStatus, error) {
 	// seek to beginning of file in preparation for Import()
 	if _, err := archiveFile.Seek(0, 0); err != nil {
 		return nil, nil, err
 	}
 
-	images, err := client.Import(
-		ctx,
-		archiveFile)
+	imported, err := snap.Import(ctx, archiveFile)
 	if err != nil {
 		return nil, nil, err
 	}
 	log.Printf("imported images from %s", archiveFile.Name())
 
-	img, err := client.GetImage(ctx, images[0].Name)
-	if err != nil {
-		return nil, nil, err
-	}
-	log.Printf("found image archive %s", img.Name())
-
-	restored, err := client.Restore(ctx, "restored-nginx", img,
-		containerd.WithRestoreImage,
-		containerd.WithRestoreSpec,
-		containerd.WithRestoreRuntime)
+	restored, err := snap.Restore(ctx, "restored-nginx", imported)
 	if err != nil {
 		return nil, nil, err
 	}
 	log.Printf("started container %s", restored.ID())
 
-	task, err := restored.NewTask(ctx, cio.NewCreator(cio.WithStdio))
-	if err != nil {
-		return nil, nil, err
-	}
-
-	wait, err := task.Wait(ctx)
+	task, err := restored.NewTask(ctx, cio.NewCreator(cio.WithStdio), containerd.WithTaskCheckpoint(imported.Image))
 	if err != nil {
 		return nil, nil, err
 	}
@@ -197,10 +180,10 @@ func restore(ctx context.Context, archiveFile *os.File) (containerd.Container, <
 		return nil, nil, err
 	}
 
-	return restored, wait, nil
+	return restored, task, nil
 }
 
-func cleanup(ctx context.Context, client *containerd.Client) error {
+func cleanup(ctx context.Context, client *containerd.Client, rec *reconciler.Reconciler) error {
 	containers, err := client.Containers(ctx)
 	if err != nil {
 		return err
@@ -208,7 +191,7 @@ func cleanup(ctx context.Context, client *containerd.Client) error {
 
 	var errs []error
 	for _, container := range containers {
-		if err := remove(ctx, client, container); err != nil {
+		if err := remove(ctx, client, rec, container); err != nil {
 			errs = append(errs, err)
 		}
 	}
@@ -225,8 +208,11 @@ func cleanup(ctx context.Context, client *containerd.Client) error {
 	return finalErr
 }
 
-func remove(ctx context.Context, client *containerd.Client, container containerd.Container) error {
+func remove(ctx context.Context, client *containerd.Client, rec *reconciler.Reconciler, container containerd.Container) error {
 	log.Printf("container (%s): msg:'removing'", container.ID())
+	if oomAt, ok := rec.OOMed(container.ID()); ok {
+		log.Printf("container (%s): msg:'removing after OOM' (at:%s)", container.ID(), oomAt.Format(time.RFC3339))
+	}
 	task, err := container.Task(ctx, nil)
 	if err != nil {
 		return err
@@ -265,6 +251,7 @@ func remove(ctx context.Context, client *containerd.Client, container containerd
 		return err
 	}
 	log.Printf("container (%s): msg:'container deleted'", container.ID())
+	rec.Untrack(container.ID())
 
 	return nil
 }
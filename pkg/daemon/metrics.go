@@ -0,0 +1,33 @@
+package daemon
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// Metrics holds the Prometheus collectors the daemon exposes for
+// checkpoint duration and size, so operators can alert on slow or
+// oversized checkpoints.
+type Metrics struct {
+	CheckpointDuration prometheus.Histogram
+	CheckpointSize     prometheus.Histogram
+}
+
+// NewMetrics registers and returns the daemon's collectors against the
+// default Prometheus registry.
+func NewMetrics() *Metrics {
+	m := &Metrics{
+		CheckpointDuration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: "container_snapshotter",
+			Name:      "checkpoint_duration_seconds",
+			Help:      "Time taken to create a container checkpoint.",
+			Buckets:   prometheus.DefBuckets,
+		}),
+		CheckpointSize: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: "container_snapshotter",
+			Name:      "checkpoint_size_bytes",
+			Help:      "Size of the exported checkpoint archive.",
+			Buckets:   prometheus.ExponentialBuckets(1<<20, 2, 10),
+		}),
+	}
+
+	prometheus.MustRegister(m.CheckpointDuration, m.CheckpointSize)
+	return m
+}
@@ -0,0 +1,217 @@
+// Package daemon implements the Snapshotter gRPC service, wrapping
+// pkg/snapshotter so checkpoint/export/import/restore can be driven
+// remotely instead of only as a local one-shot flow.
+package daemon
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"time"
+
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/cio"
+	"github.com/containerd/containerd/namespaces"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	snapshotterv1 "github.com/ihcsim/container-snapshotter/api/snapshotter/v1"
+	"github.com/ihcsim/container-snapshotter/pkg/snapshotter"
+)
+
+// Server implements snapshotterv1.SnapshotterServer on top of a single
+// containerd client, dispatching every RPC under the namespace carried in
+// its request.
+type Server struct {
+	snapshotterv1.UnimplementedSnapshotterServer
+
+	client  *containerd.Client
+	metrics *Metrics
+}
+
+// NewServer returns a Server that issues containerd calls against client.
+func NewServer(client *containerd.Client, metrics *Metrics) *Server {
+	return &Server{
+		client:  client,
+		metrics: metrics,
+	}
+}
+
+func (s *Server) CreateCheckpoint(ctx context.Context, req *snapshotterv1.CreateCheckpointRequest) (*snapshotterv1.Checkpoint, error) {
+	ctx = namespaces.WithNamespace(ctx, req.Namespace)
+
+	container, err := s.client.LoadContainer(ctx, req.ContainerId)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "container %s: %v", req.ContainerId, err)
+	}
+
+	start := time.Now()
+	snap, err := snapshotter.New(s.client, req.Namespace).Checkpoint(ctx, container, req.Name)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "checkpoint %s: %v", req.ContainerId, err)
+	}
+	s.metrics.CheckpointDuration.Observe(time.Since(start).Seconds())
+
+	return toProto(req.ContainerId, snap)
+}
+
+func (s *Server) ListCheckpoints(ctx context.Context, req *snapshotterv1.ListCheckpointsRequest) (*snapshotterv1.ListCheckpointsResponse, error) {
+	ctx = namespaces.WithNamespace(ctx, req.Namespace)
+
+	images, err := s.client.ListImages(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "list checkpoints: %v", err)
+	}
+
+	resp := &snapshotterv1.ListCheckpointsResponse{}
+	for _, image := range images {
+		containerID, ok := containerIDFromRef(image.Name())
+		if !ok || (req.ContainerId != "" && containerID != req.ContainerId) {
+			continue
+		}
+
+		checkpoint, err := toProto(containerID, snapshotter.Snapshot{Image: image})
+		if err != nil {
+			return nil, err
+		}
+		resp.Checkpoints = append(resp.Checkpoints, checkpoint)
+	}
+
+	return resp, nil
+}
+
+func (s *Server) ExportCheckpoint(req *snapshotterv1.ExportCheckpointRequest, stream snapshotterv1.Snapshotter_ExportCheckpointServer) error {
+	ctx := namespaces.WithNamespace(stream.Context(), req.Namespace)
+
+	image, err := s.client.GetImage(ctx, req.Name)
+	if err != nil {
+		return status.Errorf(codes.NotFound, "checkpoint %s: %v", req.Name, err)
+	}
+
+	var buf bytes.Buffer
+	if err := snapshotter.New(s.client, req.Namespace).Export(ctx, snapshotter.Snapshot{Image: image}, &buf); err != nil {
+		return status.Errorf(codes.Internal, "export %s: %v", req.Name, err)
+	}
+	s.metrics.CheckpointSize.Observe(float64(buf.Len()))
+
+	const chunkSize = 32 * 1024
+	for buf.Len() > 0 {
+		n := chunkSize
+		if buf.Len() < n {
+			n = buf.Len()
+		}
+		if err := stream.Send(&snapshotterv1.ExportCheckpointResponse{Chunk: buf.Next(n)}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Server) ImportCheckpoint(stream snapshotterv1.Snapshotter_ImportCheckpointServer) error {
+	first, err := stream.Recv()
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "import: missing namespace message: %v", err)
+	}
+	namespace := first.GetNamespace()
+	if namespace == "" {
+		return status.Error(codes.InvalidArgument, "import: first message must set namespace")
+	}
+	ctx := namespaces.WithNamespace(stream.Context(), namespace)
+
+	var buf bytes.Buffer
+	for {
+		msg, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return status.Errorf(codes.Internal, "import: %v", err)
+		}
+		buf.Write(msg.GetChunk())
+	}
+
+	snap, err := snapshotter.New(s.client, namespace).Import(ctx, &buf)
+	if err != nil {
+		return status.Errorf(codes.Internal, "import: %v", err)
+	}
+
+	containerID, _ := containerIDFromRef(snap.Name())
+	checkpoint, err := toProto(containerID, snap)
+	if err != nil {
+		return err
+	}
+
+	return stream.SendAndClose(checkpoint)
+}
+
+func (s *Server) Restore(ctx context.Context, req *snapshotterv1.RestoreRequest) (*snapshotterv1.RestoreResponse, error) {
+	ctx = namespaces.WithNamespace(ctx, req.Namespace)
+
+	image, err := s.client.GetImage(ctx, req.Name)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "checkpoint %s: %v", req.Name, err)
+	}
+
+	snap := snapshotter.Snapshot{Image: image}
+	restored, err := snapshotter.New(s.client, req.Namespace).Restore(ctx, req.NewContainerId, snap)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "restore %s: %v", req.Name, err)
+	}
+
+	task, err := restored.NewTask(ctx, cio.NewCreator(cio.WithStdio), containerd.WithTaskCheckpoint(snap.Image))
+	if err != nil {
+		restored.Delete(ctx, containerd.WithSnapshotCleanup)
+		return nil, status.Errorf(codes.Internal, "restore task for %s: %v", req.NewContainerId, err)
+	}
+
+	if err := task.Start(ctx); err != nil {
+		restored.Delete(ctx, containerd.WithSnapshotCleanup)
+		return nil, status.Errorf(codes.Internal, "start restored task for %s: %v", req.NewContainerId, err)
+	}
+
+	return &snapshotterv1.RestoreResponse{ContainerId: restored.ID()}, nil
+}
+
+func (s *Server) DeleteCheckpoint(ctx context.Context, req *snapshotterv1.DeleteCheckpointRequest) (*empty.Empty, error) {
+	ctx = namespaces.WithNamespace(ctx, req.Namespace)
+
+	if err := s.client.ImageService().Delete(ctx, req.Name); err != nil {
+		return nil, status.Errorf(codes.Internal, "delete %s: %v", req.Name, err)
+	}
+
+	return &empty.Empty{}, nil
+}
+
+func toProto(containerID string, snap snapshotter.Snapshot) (*snapshotterv1.Checkpoint, error) {
+	createdAt, err := ptypes.TimestampProto(snap.CreatedAt())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "checkpoint %s: %v", snap.Name(), err)
+	}
+
+	return &snapshotterv1.Checkpoint{
+		Name:        snap.Name(),
+		ContainerId: containerID,
+		CreatedAt:   createdAt,
+	}, nil
+}
+
+// containerIDFromRef recovers the container ID embedded in a checkpoint
+// image reference of the form "isim.dev/checkpoint/container/<id>:<tag>".
+func containerIDFromRef(ref string) (string, bool) {
+	const prefix = "isim.dev/checkpoint/container/"
+	if len(ref) <= len(prefix) || ref[:len(prefix)] != prefix {
+		return "", false
+	}
+
+	rest := ref[len(prefix):]
+	for i := 0; i < len(rest); i++ {
+		if rest[i] == ':' {
+			return rest[:i], true
+		}
+	}
+
+	return "", false
+}
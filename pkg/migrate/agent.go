@@ -0,0 +1,85 @@
+package migrate
+
+import (
+	"bytes"
+	"context"
+	"io"
+
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/cio"
+	"github.com/containerd/containerd/namespaces"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	migratev1 "github.com/ihcsim/container-snapshotter/api/migrate/v1"
+	"github.com/ihcsim/container-snapshotter/pkg/snapshotter"
+)
+
+// Agent implements migratev1.MigrationAgentServer, the destination side of
+// a migration: it imports the streamed checkpoint image and restores the
+// container and task from it.
+type Agent struct {
+	migratev1.UnimplementedMigrationAgentServer
+
+	client *containerd.Client
+}
+
+// NewAgent returns an Agent that restores containers using client.
+func NewAgent(client *containerd.Client) *Agent {
+	return &Agent{client: client}
+}
+
+func (a *Agent) Receive(stream migratev1.MigrationAgent_ReceiveServer) error {
+	first, err := stream.Recv()
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "receive: missing metadata message: %v", err)
+	}
+	meta := first.GetMetadata()
+	if meta == nil || meta.NewContainerId == "" {
+		return status.Error(codes.InvalidArgument, "receive: first message must carry metadata with a new_container_id")
+	}
+
+	ctx := namespaces.WithNamespace(stream.Context(), meta.Namespace)
+	snap := snapshotter.New(a.client, meta.Namespace)
+
+	var buf bytes.Buffer
+	for {
+		msg, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return status.Errorf(codes.Internal, "receive: %v", err)
+		}
+		buf.Write(msg.GetChunk())
+	}
+
+	checkpoint, err := snap.Import(ctx, &buf)
+	if err != nil {
+		return status.Errorf(codes.Internal, "import: %v", err)
+	}
+
+	restored, err := snap.Restore(ctx, meta.NewContainerId, checkpoint)
+	if err != nil {
+		return status.Errorf(codes.Internal, "restore container %s: %v", meta.NewContainerId, err)
+	}
+
+	task, err := restored.NewTask(ctx, cio.NewCreator(cio.WithStdio), containerd.WithTaskCheckpoint(checkpoint.Image))
+	if err != nil {
+		a.rollback(ctx, restored)
+		return status.Errorf(codes.Internal, "restore task for %s: %v", meta.NewContainerId, err)
+	}
+
+	if err := task.Start(ctx); err != nil {
+		a.rollback(ctx, restored)
+		return status.Errorf(codes.Internal, "start restored task for %s: %v", meta.NewContainerId, err)
+	}
+
+	return stream.SendAndClose(&migratev1.ReceiveResponse{ContainerId: restored.ID()})
+}
+
+// rollback removes a partially restored container and its snapshot so a
+// failed migration doesn't leave orphaned state on the destination.
+func (a *Agent) rollback(ctx context.Context, container containerd.Container) {
+	container.Delete(ctx, containerd.WithSnapshotCleanup)
+}
@@ -0,0 +1,185 @@
+// Package migrate coordinates host-to-host live migration of a running
+// container on top of the checkpoint/restore primitives in
+// pkg/snapshotter: checkpoint on the source, stream the image over a
+// mutually-authenticated connection, and restore (including task state)
+// on the destination.
+package migrate
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"log"
+
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/namespaces"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	migratev1 "github.com/ihcsim/container-snapshotter/api/migrate/v1"
+	"github.com/ihcsim/container-snapshotter/pkg/snapshotter"
+)
+
+// Options configures a single Migrate call.
+type Options struct {
+	// PreHandoff, if set, runs before the source task is paused. It's
+	// the caller's chance to drain connections or reprogram a load
+	// balancer away from the source before it stops serving traffic.
+	PreHandoff func(ctx context.Context) error
+
+	// PostHandoff, if set, runs once the destination container is up
+	// and the source container has been deleted, so the caller can
+	// point traffic at the new location.
+	PostHandoff func(ctx context.Context) error
+
+	// Progress, if set, is called with a short description of each
+	// migration stage as it happens.
+	Progress func(stage string)
+}
+
+// Migrator drives live migrations out of a single source host.
+type Migrator struct {
+	client      *containerd.Client
+	namespace   string
+	snapshotter *snapshotter.Snapshotter
+	tlsConfig   *tls.Config
+}
+
+// NewMigrator returns a Migrator that checkpoints containers from client
+// in namespace and streams them to destination agents using tlsConfig for
+// mutual TLS.
+func NewMigrator(client *containerd.Client, namespace string, tlsConfig *tls.Config) *Migrator {
+	return &Migrator{
+		client:      client,
+		namespace:   namespace,
+		snapshotter: snapshotter.New(client, namespace),
+		tlsConfig:   tlsConfig,
+	}
+}
+
+// Migrate moves the running container identified by srcContainerID from
+// this host to the destination agent listening on dstAddr. The container
+// keeps its ID on the destination. On any failure after the source task
+// has been paused, Migrate resumes it so the source keeps serving.
+func (m *Migrator) Migrate(ctx context.Context, srcContainerID, dstAddr string, opts Options) (err error) {
+	ctx = namespaces.WithNamespace(ctx, m.namespace)
+
+	container, err := m.client.LoadContainer(ctx, srcContainerID)
+	if err != nil {
+		return fmt.Errorf("migrate: load container %s: %w", srcContainerID, err)
+	}
+
+	task, err := container.Task(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("migrate: load task for %s: %w", srcContainerID, err)
+	}
+
+	if opts.PreHandoff != nil {
+		if err := opts.PreHandoff(ctx); err != nil {
+			return fmt.Errorf("migrate: pre-handoff: %w", err)
+		}
+	}
+
+	m.report(opts, "pausing source task")
+	if err := task.Pause(ctx); err != nil {
+		return fmt.Errorf("migrate: pause source task %s: %w", srcContainerID, err)
+	}
+
+	defer func() {
+		if err != nil {
+			m.report(opts, "rolling back: resuming source task")
+			if resumeErr := task.Resume(ctx); resumeErr != nil {
+				log.Printf("migrate: resume source task %s after failed migration: %v", srcContainerID, resumeErr)
+			}
+		}
+	}()
+
+	m.report(opts, "checkpointing source container")
+	snapshot, err := m.snapshotter.Checkpoint(ctx, container, "", containerd.WithCheckpointRuntime, containerd.WithCheckpointTask)
+	if err != nil {
+		return fmt.Errorf("migrate: checkpoint %s: %w", srcContainerID, err)
+	}
+
+	m.report(opts, "streaming checkpoint to destination")
+	if err := m.send(ctx, dstAddr, srcContainerID, snapshot); err != nil {
+		return fmt.Errorf("migrate: send checkpoint to %s: %w", dstAddr, err)
+	}
+
+	m.report(opts, "deleting source container")
+	if _, delErr := task.Delete(ctx); delErr != nil {
+		log.Printf("migrate: delete source task %s: %v", srcContainerID, delErr)
+	}
+	if delErr := container.Delete(ctx, containerd.WithSnapshotCleanup); delErr != nil {
+		log.Printf("migrate: delete source container %s: %v", srcContainerID, delErr)
+	}
+
+	if opts.PostHandoff != nil {
+		if err := opts.PostHandoff(ctx); err != nil {
+			return fmt.Errorf("migrate: post-handoff: %w", err)
+		}
+	}
+
+	m.report(opts, "migration complete")
+	return nil
+}
+
+// send dials the destination agent over mTLS and streams the checkpoint
+// image to it.
+func (m *Migrator) send(ctx context.Context, dstAddr, newContainerID string, snapshot snapshotter.Snapshot) error {
+	conn, err := grpc.DialContext(ctx, dstAddr, grpc.WithTransportCredentials(credentials.NewTLS(m.tlsConfig)))
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	stream, err := migratev1.NewMigrationAgentClient(conn).Receive(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := stream.Send(&migratev1.ReceiveRequest{
+		Data: &migratev1.ReceiveRequest_Metadata{
+			Metadata: &migratev1.ReceiveMetadata{
+				Namespace:      m.namespace,
+				NewContainerId: newContainerID,
+			},
+		},
+	}); err != nil {
+		return err
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		defer pw.Close()
+		if err := m.snapshotter.Export(ctx, snapshot, pw); err != nil {
+			pw.CloseWithError(err)
+		}
+	}()
+
+	buf := make([]byte, 32*1024)
+	for {
+		n, readErr := pr.Read(buf)
+		if n > 0 {
+			chunk := append([]byte(nil), buf[:n]...)
+			if sendErr := stream.Send(&migratev1.ReceiveRequest{Data: &migratev1.ReceiveRequest_Chunk{Chunk: chunk}}); sendErr != nil {
+				return sendErr
+			}
+		}
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			return readErr
+		}
+	}
+
+	_, err = stream.CloseAndRecv()
+	return err
+}
+
+func (m *Migrator) report(opts Options, stage string) {
+	if opts.Progress != nil {
+		opts.Progress(stage)
+	}
+}
@@ -0,0 +1,225 @@
+// Package reconciler replaces a single task-exit wait with an
+// event-driven supervisor, modeled on libcontainerd's client_daemon: it
+// subscribes to containerd's task events for every tracked container and
+// keeps their state, exec'd processes, and OOM history up to date.
+package reconciler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"syscall"
+	"time"
+
+	apievents "github.com/containerd/containerd/api/events"
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/cio"
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/typeurl/v2"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+)
+
+// trackedContainer holds the reconciler's view of a single managed
+// container: its task, any exec'd processes, and whether it has OOM'd.
+type trackedContainer struct {
+	container containerd.Container
+	task      containerd.Task
+	execs     map[string]containerd.Process
+
+	oom   bool
+	oomAt time.Time
+}
+
+// Reconciler tracks a set of containers and keeps them in sync with
+// containerd's task event stream, rather than only waiting on the
+// initial task's exit channel.
+type Reconciler struct {
+	client    *containerd.Client
+	namespace string
+
+	mu         sync.Mutex
+	containers map[string]*trackedContainer
+}
+
+// New returns a Reconciler that applies client's events for namespace.
+func New(client *containerd.Client, namespace string) *Reconciler {
+	return &Reconciler{
+		client:     client,
+		namespace:  namespace,
+		containers: make(map[string]*trackedContainer),
+	}
+}
+
+// Track adds a container and its task to the managed set.
+func (r *Reconciler) Track(container containerd.Container, task containerd.Task) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.containers[container.ID()] = &trackedContainer{
+		container: container,
+		task:      task,
+		execs:     make(map[string]containerd.Process),
+	}
+}
+
+// Untrack removes a container from the managed set.
+func (r *Reconciler) Untrack(id string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.containers, id)
+}
+
+// OOMed reports whether the tracked container has hit an OOM since it was
+// added, so callers like remove() can report it as the reason a
+// container's task went away.
+func (r *Reconciler) OOMed(id string) (at time.Time, ok bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	tracked, found := r.containers[id]
+	if !found || !tracked.oom {
+		return time.Time{}, false
+	}
+	return tracked.oomAt, true
+}
+
+// Run subscribes to TaskExit, TaskOOM, TaskPaused, TaskCheckpointed, and
+// TaskDelete events across the reconciler's namespace and applies them to
+// the tracked set until ctx is canceled.
+func (r *Reconciler) Run(ctx context.Context) error {
+	eventCh, errCh := r.client.EventService().Subscribe(ctx,
+		`topic=="/tasks/exit"`,
+		`topic=="/tasks/oom"`,
+		`topic=="/tasks/paused"`,
+		`topic=="/tasks/checkpointed"`,
+		`topic=="/tasks/delete"`,
+	)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case err := <-errCh:
+			return err
+		case envelope := <-eventCh:
+			if envelope.Namespace != r.namespace {
+				continue
+			}
+			event, err := typeurl.UnmarshalAny(envelope.Event)
+			if err != nil {
+				log.Printf("reconciler: unmarshal event %s: %v", envelope.Topic, err)
+				continue
+			}
+			r.apply(event)
+		}
+	}
+}
+
+func (r *Reconciler) apply(event interface{}) {
+	switch e := event.(type) {
+	case *apievents.TaskExit:
+		log.Printf("container (%s): msg:'task exited' (code:%d)", e.ContainerID, e.ExitStatus)
+
+	case *apievents.TaskOOM:
+		r.mu.Lock()
+		if tracked, ok := r.containers[e.ContainerID]; ok {
+			tracked.oom = true
+			tracked.oomAt = time.Now()
+		}
+		r.mu.Unlock()
+		log.Printf("container (%s): msg:'out of memory'", e.ContainerID)
+
+	case *apievents.TaskPaused:
+		log.Printf("container (%s): msg:'task paused'", e.ContainerID)
+
+	case *apievents.TaskCheckpointed:
+		log.Printf("container (%s): msg:'task checkpointed'", e.ContainerID)
+
+	case *apievents.TaskDelete:
+		r.mu.Lock()
+		delete(r.containers, e.ContainerID)
+		r.mu.Unlock()
+		log.Printf("container (%s): msg:'task deleted'", e.ContainerID)
+	}
+}
+
+// Exec starts a new process identified by execID inside containerID's
+// task.
+func (r *Reconciler) Exec(ctx context.Context, containerID, execID string, spec *specs.Process, ioCreator cio.Creator) (containerd.Process, error) {
+	tracked, err := r.lookup(containerID)
+	if err != nil {
+		return nil, err
+	}
+
+	process, err := tracked.task.Exec(ctx, execID, spec, ioCreator)
+	if err != nil {
+		return nil, fmt.Errorf("reconciler: exec %s in %s: %w", execID, containerID, err)
+	}
+
+	if err := process.Start(ctx); err != nil {
+		return nil, fmt.Errorf("reconciler: start exec %s in %s: %w", execID, containerID, err)
+	}
+
+	r.mu.Lock()
+	tracked.execs[execID] = process
+	r.mu.Unlock()
+
+	return process, nil
+}
+
+// Attach reattaches to containerID's task IO, replacing the tracked task
+// handle with the reattached one.
+func (r *Reconciler) Attach(ctx context.Context, containerID string, ioAttach cio.Attach) error {
+	tracked, err := r.lookup(containerID)
+	if err != nil {
+		return err
+	}
+
+	task, err := tracked.container.Task(ctx, ioAttach)
+	if err != nil {
+		return fmt.Errorf("reconciler: attach to %s: %w", containerID, err)
+	}
+
+	r.mu.Lock()
+	tracked.task = task
+	r.mu.Unlock()
+
+	return nil
+}
+
+// Pause suspends the given container's task.
+func (r *Reconciler) Pause(ctx context.Context, containerID string) error {
+	tracked, err := r.lookup(containerID)
+	if err != nil {
+		return err
+	}
+	return tracked.task.Pause(ctx)
+}
+
+// Resume continues a paused container's task.
+func (r *Reconciler) Resume(ctx context.Context, containerID string) error {
+	tracked, err := r.lookup(containerID)
+	if err != nil {
+		return err
+	}
+	return tracked.task.Resume(ctx)
+}
+
+// Kill signals the given container's task.
+func (r *Reconciler) Kill(ctx context.Context, containerID string, sig syscall.Signal) error {
+	tracked, err := r.lookup(containerID)
+	if err != nil {
+		return err
+	}
+	return tracked.task.Kill(ctx, sig)
+}
+
+func (r *Reconciler) lookup(containerID string) (*trackedContainer, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tracked, ok := r.containers[containerID]
+	if !ok {
+		return nil, errdefs.ErrNotFound
+	}
+	return tracked, nil
+}
@@ -0,0 +1,86 @@
+package reconciler
+
+import (
+	"context"
+	"syscall"
+	"testing"
+
+	"github.com/containerd/containerd"
+	apievents "github.com/containerd/containerd/api/events"
+	"github.com/containerd/containerd/cio"
+	"github.com/containerd/containerd/containers"
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/oci"
+	"github.com/containerd/typeurl/v2"
+)
+
+// fakeContainer is a minimal containerd.Container that only needs to
+// carry an ID for the reconciler's tracked-set bookkeeping under test.
+type fakeContainer struct {
+	id string
+}
+
+func (f fakeContainer) ID() string { return f.id }
+func (f fakeContainer) Info(context.Context, ...containerd.InfoOpts) (containers.Container, error) {
+	return containers.Container{}, nil
+}
+func (f fakeContainer) Delete(context.Context, ...containerd.DeleteOpts) error { return nil }
+func (f fakeContainer) NewTask(context.Context, cio.Creator, ...containerd.NewTaskOpts) (containerd.Task, error) {
+	return nil, nil
+}
+func (f fakeContainer) Spec(context.Context) (*oci.Spec, error)                   { return nil, nil }
+func (f fakeContainer) Task(context.Context, cio.Attach) (containerd.Task, error) { return nil, nil }
+func (f fakeContainer) Image(context.Context) (containerd.Image, error)           { return nil, nil }
+func (f fakeContainer) Labels(context.Context) (map[string]string, error)         { return nil, nil }
+func (f fakeContainer) SetLabels(context.Context, map[string]string) (map[string]string, error) {
+	return nil, nil
+}
+func (f fakeContainer) Extensions(context.Context) (map[string]typeurl.Any, error)      { return nil, nil }
+func (f fakeContainer) Update(context.Context, ...containerd.UpdateContainerOpts) error { return nil }
+func (f fakeContainer) Checkpoint(context.Context, string, ...containerd.CheckpointOpts) (containerd.Image, error) {
+	return nil, nil
+}
+
+func TestReconcilerApplyTracksOOM(t *testing.T) {
+	r := New(nil, "example")
+	r.Track(fakeContainer{id: "c1"}, nil)
+
+	r.apply(&apievents.TaskOOM{ContainerID: "c1"})
+
+	if _, ok := r.OOMed("c1"); !ok {
+		t.Fatal("expected c1 to be marked OOMed")
+	}
+}
+
+func TestReconcilerApplyIgnoresUntrackedOOM(t *testing.T) {
+	r := New(nil, "example")
+
+	r.apply(&apievents.TaskOOM{ContainerID: "unknown"})
+
+	if _, ok := r.OOMed("unknown"); ok {
+		t.Fatal("expected untracked container to not be marked OOMed")
+	}
+}
+
+func TestReconcilerApplyDeleteUntracks(t *testing.T) {
+	r := New(nil, "example")
+	r.Track(fakeContainer{id: "c1"}, nil)
+	r.apply(&apievents.TaskOOM{ContainerID: "c1"})
+
+	r.apply(&apievents.TaskDelete{ContainerID: "c1"})
+
+	if _, ok := r.OOMed("c1"); ok {
+		t.Fatal("expected c1 to be untracked after TaskDelete")
+	}
+	if _, err := r.lookup("c1"); !errdefs.IsNotFound(err) {
+		t.Fatalf("lookup(c1) = %v, want not found", err)
+	}
+}
+
+func TestReconcilerKillUnknownContainer(t *testing.T) {
+	r := New(nil, "example")
+
+	if err := r.Kill(context.Background(), "unknown", syscall.SIGKILL); !errdefs.IsNotFound(err) {
+		t.Fatalf("Kill(unknown) = %v, want not found", err)
+	}
+}
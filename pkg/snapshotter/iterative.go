@@ -0,0 +1,43 @@
+package snapshotter
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/containerd/containerd"
+)
+
+// IterativeOpts holds the parameters of a pre-copy checkpoint.
+// IterativeCheckpoint accepts them but does not read them: it takes no
+// pre-dump rounds and no checkpoint at all.
+type IterativeOpts struct {
+	// MaxRounds is the maximum number of pre-dump rounds before the
+	// final freeze.
+	MaxRounds int
+
+	// MinDirtyPageDelta is the minimum number of pages a pre-dump round
+	// sheds relative to the previous one for another round to follow.
+	MinDirtyPageDelta int
+
+	// MaxDowntime is the time budget for the final stop-the-world freeze.
+	MaxDowntime time.Duration
+}
+
+// ErrIterativeCheckpointUnsupported is returned by IterativeCheckpoint:
+// see the method's doc comment for why.
+var ErrIterativeCheckpointUnsupported = errors.New("snapshotter: iterative pre-copy checkpointing is not supported by this containerd version")
+
+// IterativeCheckpoint always returns ErrIterativeCheckpointUnsupported
+// and an empty Snapshot. It does not read container or opts, runs no CRIU
+// pre-dump, and does not fall back to a plain checkpoint.
+//
+// containerd's public Go API has no hook for CRIU's pre-dump/parent-image
+// chaining: there is no WithCheckpointTaskPreDump CheckpointOpts, and
+// github.com/containerd/containerd/runtime/v2/runc/options.CheckpointOptions
+// has no parent-image-path field, so pre-copy rounds can't be built on
+// top of Checkpoint/container.Checkpoint the way the rest of this package
+// is.
+func (s *Snapshotter) IterativeCheckpoint(ctx context.Context, container containerd.Container, opts IterativeOpts) (Snapshot, error) {
+	return Snapshot{}, ErrIterativeCheckpointUnsupported
+}
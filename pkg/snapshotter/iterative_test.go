@@ -0,0 +1,16 @@
+package snapshotter
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestIterativeCheckpointUnsupported(t *testing.T) {
+	s := New(nil, "example")
+
+	_, err := s.IterativeCheckpoint(context.Background(), nil, IterativeOpts{})
+	if !errors.Is(err, ErrIterativeCheckpointUnsupported) {
+		t.Fatalf("IterativeCheckpoint error = %v, want %v", err, ErrIterativeCheckpointUnsupported)
+	}
+}
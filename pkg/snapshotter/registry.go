@@ -0,0 +1,85 @@
+package snapshotter
+
+import (
+	"context"
+
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/remotes"
+	"github.com/containerd/containerd/remotes/docker"
+	"github.com/containerd/containerd/remotes/docker/config"
+	dockercliconfig "github.com/docker/cli/cli/config"
+)
+
+// RegistryOptions configures how NewResolver talks to an OCI registry.
+type RegistryOptions struct {
+	// HostsDir points at a containerd-style hosts.toml directory (see
+	// ctr's --hosts-dir) for per-registry mirror and insecure-registry
+	// configuration. Defaults to /etc/containerd/certs.d when empty.
+	HostsDir string
+
+	// PlainHTTP allows pushing/pulling over plain HTTP, for insecure
+	// registries that don't terminate TLS.
+	PlainHTTP bool
+}
+
+// NewResolver returns a docker remotes.Resolver that authenticates using
+// the local docker config (~/.docker/config.json) and honors any
+// mirror/insecure-registry configuration found under opts.HostsDir.
+func NewResolver(opts RegistryOptions) (remotes.Resolver, error) {
+	hostsDir := opts.HostsDir
+	if hostsDir == "" {
+		hostsDir = "/etc/containerd/certs.d"
+	}
+
+	hosts := config.ConfigureHosts(context.Background(), config.HostOptions{
+		HostDir:       config.HostDirFromRoot(hostsDir),
+		Credentials:   dockerCredentials,
+		DefaultScheme: defaultScheme(opts.PlainHTTP),
+	})
+
+	return docker.NewResolver(docker.ResolverOptions{Hosts: hosts}), nil
+}
+
+func defaultScheme(plainHTTP bool) string {
+	if plainHTTP {
+		return "http"
+	}
+	return "https"
+}
+
+// dockerCredentials looks up credentials for host from the local docker
+// config, the same file `docker login` writes to.
+func dockerCredentials(host string) (string, string, error) {
+	cfg, err := dockercliconfig.Load("")
+	if err != nil {
+		return "", "", err
+	}
+
+	auth, err := cfg.GetAuthConfig(host)
+	if err != nil {
+		return "", "", err
+	}
+
+	return auth.Username, auth.Password, nil
+}
+
+// PushCheckpoint pushes the snapshot's checkpoint image to ref using
+// resolver. Because checkpoint images are content-addressed, repeated
+// pushes of checkpoints sharing the same rootfs layers only upload the
+// diff layers that the registry doesn't already have; the
+// io.containerd.checkpoint.runtime and checkpoint.snapshotter labels set
+// at checkpoint time are carried along as part of the image manifest.
+func (s *Snapshotter) PushCheckpoint(ctx context.Context, snapshot Snapshot, ref string, resolver remotes.Resolver) error {
+	return s.client.Push(ctx, ref, snapshot.Image.Target(), containerd.WithResolver(resolver))
+}
+
+// PullCheckpoint pulls a checkpoint image from ref using resolver, ready
+// to be handed to Restore.
+func (s *Snapshotter) PullCheckpoint(ctx context.Context, ref string, resolver remotes.Resolver) (Snapshot, error) {
+	image, err := s.client.Pull(ctx, ref, containerd.WithResolver(resolver))
+	if err != nil {
+		return Snapshot{}, err
+	}
+
+	return Snapshot{Image: image}, nil
+}
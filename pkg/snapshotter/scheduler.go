@@ -0,0 +1,180 @@
+package snapshotter
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/containerd/containerd"
+)
+
+// RetentionPolicy bounds how many checkpoints the Scheduler keeps for a
+// single container. A checkpoint is pruned once it violates either limit;
+// a zero value disables that limit.
+type RetentionPolicy struct {
+	// KeepLast is the number of most recent checkpoints to retain.
+	KeepLast int
+
+	// MaxAge is the maximum age of a checkpoint before it is pruned.
+	MaxAge time.Duration
+}
+
+// Scheduler periodically checkpoints a set of watched containers and
+// rotates old checkpoints out according to a RetentionPolicy.
+type Scheduler struct {
+	snapshotter *Snapshotter
+	interval    time.Duration
+	retention   RetentionPolicy
+
+	mu         sync.Mutex
+	containers map[string]containerd.Container
+	history    map[string][]Snapshot
+
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewScheduler returns a Scheduler that takes a checkpoint of every watched
+// container on every tick of interval, applying retention after each round.
+func NewScheduler(snapshotter *Snapshotter, interval time.Duration, retention RetentionPolicy) *Scheduler {
+	return &Scheduler{
+		snapshotter: snapshotter,
+		interval:    interval,
+		retention:   retention,
+		containers:  make(map[string]containerd.Container),
+		history:     make(map[string][]Snapshot),
+		stop:        make(chan struct{}),
+	}
+}
+
+// Watch adds a container to the set checkpointed on every tick.
+func (s *Scheduler) Watch(container containerd.Container) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.containers[container.ID()] = container
+}
+
+// Unwatch removes a container from the managed set.
+func (s *Scheduler) Unwatch(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.containers, id)
+	delete(s.history, id)
+}
+
+// Start blocks, taking a checkpoint of every watched container on each
+// tick of the configured interval, until ctx is canceled or Stop is called.
+func (s *Scheduler) Start(ctx context.Context) error {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-s.stop:
+			return nil
+		case <-ticker.C:
+			s.runOnce(ctx)
+		}
+	}
+}
+
+// Stop ends a running scheduler loop. It is safe to call more than once.
+func (s *Scheduler) Stop() {
+	s.stopOnce.Do(func() { close(s.stop) })
+}
+
+func (s *Scheduler) runOnce(ctx context.Context) {
+	s.mu.Lock()
+	containers := make([]containerd.Container, 0, len(s.containers))
+	for _, c := range s.containers {
+		containers = append(containers, c)
+	}
+	s.mu.Unlock()
+
+	for _, container := range containers {
+		snapshot, err := s.snapshotter.Checkpoint(ctx, container, "")
+		if err != nil {
+			log.Printf("scheduler: checkpoint %s: %v", container.ID(), err)
+			continue
+		}
+
+		s.mu.Lock()
+		s.history[container.ID()] = append(s.history[container.ID()], snapshot)
+		s.mu.Unlock()
+
+		if err := s.rotate(ctx, container.ID()); err != nil {
+			log.Printf("scheduler: rotate %s: %v", container.ID(), err)
+		}
+	}
+}
+
+// rotate prunes checkpoints for the given container that violate the
+// configured RetentionPolicy, oldest first.
+func (s *Scheduler) rotate(ctx context.Context, id string) error {
+	s.mu.Lock()
+	history := append([]Snapshot(nil), s.history[id]...)
+	s.mu.Unlock()
+
+	keep, prune := partitionHistory(history, s.retention, time.Now())
+
+	var errs []error
+	for _, snap := range prune {
+		if err := s.snapshotter.client.ImageService().Delete(ctx, snap.Name()); err != nil {
+			errs = append(errs, err)
+			// keep snapshots we failed to delete in history so the next
+			// rotate retries them instead of leaking an untracked image.
+			keep = append(keep, snap)
+			continue
+		}
+	}
+
+	s.mu.Lock()
+	s.history[id] = keep
+	s.mu.Unlock()
+
+	var finalErr error
+	for _, e := range errs {
+		if finalErr == nil {
+			finalErr = e
+			continue
+		}
+		finalErr = fmt.Errorf("%s\n%s", finalErr, e)
+	}
+
+	return finalErr
+}
+
+// partitionHistory splits history into the snapshots that satisfy
+// retention and the ones that violate it, oldest first. It takes now as a
+// parameter, rather than calling time.Now() itself, so the decision is a
+// pure function of its inputs.
+func partitionHistory(history []Snapshot, retention RetentionPolicy, now time.Time) (keep, prune []Snapshot) {
+	keep = append([]Snapshot(nil), history...)
+	sort.Slice(keep, func(i, j int) bool {
+		return keep[i].CreatedAt().Before(keep[j].CreatedAt())
+	})
+
+	if retention.KeepLast > 0 && len(keep) > retention.KeepLast {
+		prune = append(prune, keep[:len(keep)-retention.KeepLast]...)
+		keep = keep[len(keep)-retention.KeepLast:]
+	}
+	if retention.MaxAge > 0 {
+		cutoff := now.Add(-retention.MaxAge)
+		var kept []Snapshot
+		for _, snap := range keep {
+			if snap.CreatedAt().Before(cutoff) {
+				prune = append(prune, snap)
+				continue
+			}
+			kept = append(kept, snap)
+		}
+		keep = kept
+	}
+
+	return keep, prune
+}
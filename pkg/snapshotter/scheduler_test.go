@@ -0,0 +1,125 @@
+package snapshotter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/content"
+	"github.com/containerd/containerd/images"
+	"github.com/containerd/platforms"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// fakeImage is a minimal containerd.Image that only needs to carry a name
+// and creation time for the retention math under test.
+type fakeImage struct {
+	name      string
+	createdAt time.Time
+}
+
+func (f fakeImage) Name() string                                                  { return f.name }
+func (f fakeImage) Target() ocispec.Descriptor                                    { return ocispec.Descriptor{} }
+func (f fakeImage) Labels() map[string]string                                     { return nil }
+func (f fakeImage) Unpack(context.Context, string, ...containerd.UnpackOpt) error { return nil }
+func (f fakeImage) RootFS(context.Context) ([]digest.Digest, error)               { return nil, nil }
+func (f fakeImage) Size(context.Context) (int64, error)                           { return 0, nil }
+func (f fakeImage) Usage(context.Context, ...containerd.UsageOpt) (int64, error) {
+	return 0, nil
+}
+func (f fakeImage) Config(context.Context) (ocispec.Descriptor, error) {
+	return ocispec.Descriptor{}, nil
+}
+func (f fakeImage) IsUnpacked(context.Context, string) (bool, error) { return false, nil }
+func (f fakeImage) ContentStore() content.Store                      { return nil }
+func (f fakeImage) Metadata() images.Image                           { return images.Image{CreatedAt: f.createdAt} }
+func (f fakeImage) Platform() platforms.MatchComparer                { return nil }
+func (f fakeImage) Spec(context.Context) (ocispec.Image, error)      { return ocispec.Image{}, nil }
+
+func snapshotAt(name string, createdAt time.Time) Snapshot {
+	return Snapshot{Image: fakeImage{name: name, createdAt: createdAt}}
+}
+
+func TestPartitionHistoryKeepLast(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	history := []Snapshot{
+		snapshotAt("c", now.Add(2*time.Minute)),
+		snapshotAt("a", now),
+		snapshotAt("b", now.Add(time.Minute)),
+	}
+
+	keep, prune := partitionHistory(history, RetentionPolicy{KeepLast: 2}, now)
+
+	if got := names(keep); !equal(got, []string{"b", "c"}) {
+		t.Fatalf("keep = %v, want [b c]", got)
+	}
+	if got := names(prune); !equal(got, []string{"a"}) {
+		t.Fatalf("prune = %v, want [a]", got)
+	}
+}
+
+func TestPartitionHistoryMaxAge(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	history := []Snapshot{
+		snapshotAt("stale", now.Add(-time.Hour)),
+		snapshotAt("fresh", now.Add(-time.Minute)),
+	}
+
+	keep, prune := partitionHistory(history, RetentionPolicy{MaxAge: 10 * time.Minute}, now)
+
+	if got := names(keep); !equal(got, []string{"fresh"}) {
+		t.Fatalf("keep = %v, want [fresh]", got)
+	}
+	if got := names(prune); !equal(got, []string{"stale"}) {
+		t.Fatalf("prune = %v, want [stale]", got)
+	}
+}
+
+func TestPartitionHistoryNoLimits(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	history := []Snapshot{snapshotAt("a", now), snapshotAt("b", now.Add(time.Minute))}
+
+	keep, prune := partitionHistory(history, RetentionPolicy{}, now)
+
+	if len(prune) != 0 {
+		t.Fatalf("prune = %v, want none", names(prune))
+	}
+	if got := names(keep); !equal(got, []string{"a", "b"}) {
+		t.Fatalf("keep = %v, want [a b]", got)
+	}
+}
+
+func TestSchedulerStopIsIdempotent(t *testing.T) {
+	s := NewScheduler(New(nil, "example"), time.Minute, RetentionPolicy{})
+
+	defer func() {
+		if r := recover(); r != nil {
+			t.Fatalf("Stop panicked on repeat call: %v", r)
+		}
+	}()
+
+	s.Stop()
+	s.Stop()
+}
+
+func names(snapshots []Snapshot) []string {
+	out := make([]string, len(snapshots))
+	for i, s := range snapshots {
+		out[i] = s.Name()
+	}
+	return out
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}